@@ -0,0 +1,97 @@
+package log
+
+import (
+	"net"
+	"sync"
+)
+
+// A ConnWriter is a Writer that delivers records to a network destination
+// over TCP, UDP, or a Unix domain socket, automatically reconnecting after
+// a write error.
+type ConnWriter struct {
+	// Network and Addr are passed to net.Dial, e.g. ("tcp", "host:port"),
+	// ("udp", "host:port"), or ("unix", "/path/to/socket").
+	Network string
+	Addr    string
+
+	// ReconnectEachWrite, if set, closes and re-dials the connection
+	// around every Write instead of keeping it open between writes.
+	ReconnectEachWrite bool
+
+	// Level is the minimum Level ConnWriter is Writable at.
+	Level Level
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewConnWriter creates a ConnWriter that dials (network, addr) on demand,
+// accepting records at lv and more severe.
+func NewConnWriter(network, addr string, lv Level) *ConnWriter {
+	return &ConnWriter{Network: network, Addr: addr, Level: lv}
+}
+
+// Writable reports whether w accepts records at Level lv.
+func (w *ConnWriter) Writable(lv Level) bool {
+	return lv >= w.Level
+}
+
+// Write sends p over w's connection, dialing it first if necessary and
+// redialing once if the write fails.
+func (w *ConnWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ReconnectEachWrite {
+		w.closeLocked()
+	}
+
+	if w.conn == nil {
+		if err := w.dialLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = w.conn.Write(p)
+	if err != nil {
+		w.closeLocked()
+
+		if dialErr := w.dialLocked(); dialErr == nil {
+			n, err = w.conn.Write(p)
+		}
+	}
+
+	if w.ReconnectEachWrite {
+		w.closeLocked()
+	}
+
+	return n, err
+}
+
+// Close closes w's underlying connection, if any.
+func (w *ConnWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closeLocked()
+
+	return nil
+}
+
+func (w *ConnWriter) dialLocked() error {
+	conn, err := net.Dial(w.Network, w.Addr)
+	if err != nil {
+		return err
+	}
+
+	w.conn = conn
+
+	return nil
+}
+
+func (w *ConnWriter) closeLocked() {
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+}
@@ -0,0 +1,136 @@
+package log
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// A ContextExtractor extracts Fields from a context.Context, for use with
+// Logger.Extractor and WithContext.
+type ContextExtractor func(ctx context.Context) Fields
+
+// DefaultContextExtractor extracts the request ID, trace ID, and user ID
+// set by WithRequestID, WithTraceID, and WithUserID, when present.
+func DefaultContextExtractor(ctx context.Context) Fields {
+	var fields Fields
+
+	set := func(key string, v string, ok bool) {
+		if !ok {
+			return
+		}
+
+		if fields == nil {
+			fields = make(Fields, 3)
+		}
+
+		fields[key] = v
+	}
+
+	id, ok := RequestIDFromContext(ctx)
+	set("request_id", id, ok)
+
+	id, ok = TraceIDFromContext(ctx)
+	set("trace_id", id, ok)
+
+	id, ok = UserIDFromContext(ctx)
+	set("user_id", id, ok)
+
+	return fields
+}
+
+// FromContext extracts Fields from ctx using DefaultContextExtractor.
+func FromContext(ctx context.Context) Fields {
+	return DefaultContextExtractor(ctx)
+}
+
+type contextKey int
+
+const (
+	contextKeyRequestID contextKey = iota
+	contextKeyTraceID
+	contextKeyUserID
+)
+
+// WithRequestID returns a Context carrying id as the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeyRequestID, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by
+// WithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKeyRequestID).(string)
+	return id, ok
+}
+
+// WithTraceID returns a Context carrying id as the trace ID.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeyTraceID, id)
+}
+
+// TraceIDFromContext returns the trace ID stored in ctx by WithTraceID, if
+// any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKeyTraceID).(string)
+	return id, ok
+}
+
+// WithUserID returns a Context carrying id as the user ID.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeyUserID, id)
+}
+
+// UserIDFromContext returns the user ID stored in ctx by WithUserID, if
+// any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKeyUserID).(string)
+	return id, ok
+}
+
+// WithContext returns a derived Logger carrying the Fields that l.Extractor
+// (DefaultContextExtractor if l.Extractor is nil) extracts from ctx, merged
+// with l's existing fields as in WithFields. The result is cheap: it shares
+// everything with l except the field map.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	return l.WithFields(l.extractContext(ctx))
+}
+
+func (l *Logger) extractContext(ctx context.Context) Fields {
+	extractor := l.Extractor
+	if extractor == nil {
+		extractor = DefaultContextExtractor
+	}
+
+	return extractor(ctx)
+}
+
+// logCtx builds a Record for msg at lv, attaching Fields extracted from
+// ctx in addition to l's own fields, and routes it to the underlying
+// Writer: directly, as Fields, if it is a RecordWriter willing to accept
+// lv; otherwise as a formatted line with the context Fields (request ID,
+// trace ID, user ID, ...) prepended ahead of msg, and l's own persistent
+// Fields (set via WithFields) appended after it, as logw does for Infow
+// and friends.
+func (l *Logger) logCtx(lv Level, ctx context.Context, msg string) {
+	msg = strings.TrimSuffix(msg, "\n")
+	ctxFields := l.extractContext(ctx)
+
+	if rw, ok := l.Writer.(RecordWriter); ok && rw.Writable(lv) {
+		fields := l.fields.clone()
+
+		for k, v := range ctxFields {
+			if fields == nil {
+				fields = make(Fields, len(ctxFields))
+			}
+
+			fields[k] = v
+		}
+
+		rw.WriteRecord(Record{Time: time.Now(), Level: lv, Msg: msg, Fields: fields})
+
+		return
+	}
+
+	l.Get(lv).Print(appendFields(prependFields(ctxFields, msg), l.fields))
+}
@@ -0,0 +1,20 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestLogCtxPrependsContextFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(NewConsoleWriter(&buf, false, LevelTrace), "", 0)
+	ctx := WithRequestID(context.Background(), "req-123")
+	l.InfoCtx(ctx, "handling request")
+
+	want := "[INFO] request_id=req-123 handling request\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
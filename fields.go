@@ -0,0 +1,20 @@
+package log
+
+// Fields is a set of key/value pairs attached to a log record produced by
+// Logger's *w methods (Infow, Errorw, and so on).
+type Fields map[string]interface{}
+
+// clone returns a copy of f so that a child Logger can extend its fields
+// without racing with the parent or any of its other children.
+func (f Fields) clone() Fields {
+	if f == nil {
+		return nil
+	}
+
+	c := make(Fields, len(f))
+	for k, v := range f {
+		c[k] = v
+	}
+
+	return c
+}
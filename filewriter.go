@@ -0,0 +1,167 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// A FileWriter is a Writer that appends to a file, rotating it once it
+// would exceed MaxSize bytes or MaxAge has elapsed since it was opened,
+// whichever comes first. A zero MaxSize or MaxAge disables that trigger.
+// Rotated files are renamed with a timestamp suffix and, if Compress is
+// set, gzipped in the background.
+type FileWriter struct {
+	// Path is the file FileWriter appends to.
+	Path string
+
+	// MaxSize is the size in bytes at which the file is rotated. Zero
+	// disables size-based rotation.
+	MaxSize int64
+
+	// MaxAge is the duration after opening at which the file is rotated.
+	// Zero disables time-based rotation.
+	MaxAge time.Duration
+
+	// Compress gzips a file once it has been rotated out.
+	Compress bool
+
+	// Level is the minimum Level FileWriter is Writable at.
+	Level Level
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewFileWriter creates a FileWriter that appends to path, rotating at
+// maxSize bytes or maxAge, whichever comes first, and accepting records at
+// lv and more severe. A zero maxSize or maxAge disables that trigger.
+func NewFileWriter(path string, maxSize int64, maxAge time.Duration, lv Level) *FileWriter {
+	return &FileWriter{Path: path, MaxSize: maxSize, MaxAge: maxAge, Level: lv}
+}
+
+// Writable reports whether w accepts records at Level lv.
+func (w *FileWriter) Writable(lv Level) bool {
+	return lv >= w.Level
+}
+
+// Write appends p to w's file, rotating first if necessary.
+func (w *FileWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotateIfNeeded(int64(len(p))); err != nil {
+		return 0, err
+	}
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+// Close closes w's underlying file, if open.
+func (w *FileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+
+	return err
+}
+
+func (w *FileWriter) open() error {
+	f, err := os.OpenFile(w.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w.file = f
+	w.size = fi.Size()
+	w.opened = time.Now()
+
+	return nil
+}
+
+func (w *FileWriter) rotateIfNeeded(next int64) error {
+	if w.file == nil {
+		return nil
+	}
+
+	needsRotate := (w.MaxSize > 0 && w.size+next > w.MaxSize) ||
+		(w.MaxAge > 0 && time.Since(w.opened) >= w.MaxAge)
+	if !needsRotate {
+		return nil
+	}
+
+	return w.rotate()
+}
+
+func (w *FileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	w.file = nil
+
+	rotated := fmt.Sprintf("%s.%s", w.Path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(w.Path, rotated); err != nil {
+		return err
+	}
+
+	if w.Compress {
+		go compressFile(rotated)
+	}
+
+	return nil
+}
+
+// compressFile gzips path in place, removing the original on success.
+func compressFile(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return
+	}
+
+	if err := gw.Close(); err != nil {
+		return
+	}
+
+	os.Remove(path)
+}
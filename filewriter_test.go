@@ -0,0 +1,85 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileWriterRotatesOnMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w := NewFileWriter(path, 10, 0, LevelTrace)
+	defer w.Close()
+
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+
+	// "hello" (5 bytes) plus "world!" (6 bytes) would exceed MaxSize, so
+	// this write must rotate the original file out before appending.
+	if _, err := w.Write([]byte("world!")); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob rotated files: %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("got %d rotated files, want 1: %v", len(matches), matches)
+	}
+
+	rotated, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("read rotated file: %v", err)
+	}
+
+	if string(rotated) != "hello" {
+		t.Fatalf("rotated file content = %q, want %q", rotated, "hello")
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read current file: %v", err)
+	}
+
+	if string(current) != "world!" {
+		t.Fatalf("current file content = %q, want %q", current, "world!")
+	}
+}
+
+func TestFileWriterWriteExceedingMaxSizeOnEmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// A single write bigger than MaxSize, against a file that is still
+	// empty (nothing to rotate), must not loop or fail: there is no prior
+	// content to rotate out, so the oversized write is simply appended.
+	w := NewFileWriter(path, 4, 0, LevelTrace)
+	defer w.Close()
+
+	if _, err := w.Write([]byte("this write exceeds MaxSize")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob rotated files: %v", err)
+	}
+
+	if len(matches) != 0 {
+		t.Fatalf("got %d rotated files, want 0: %v", len(matches), matches)
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read current file: %v", err)
+	}
+
+	if string(current) != "this write exceeds MaxSize" {
+		t.Fatalf("current file content = %q, want %q", current, "this write exceeds MaxSize")
+	}
+}
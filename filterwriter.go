@@ -0,0 +1,234 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A FilterWriter wraps a Writer, dropping or redacting records before they
+// reach it: a minimum Level, a substring or regexp match against the
+// message, and a redaction table that replaces sensitive tokens with a
+// placeholder. Configure it with FilterLevel, FilterSubstring,
+// FilterRegexp, FilterRedact, and FilterFunc.
+type FilterWriter struct {
+	w Writer
+
+	level    Level
+	substrs  [][]byte
+	re       *regexp.Regexp
+	redact   map[string][]byte
+	filterFn func(Level, []byte) bool
+
+	replacerOnce sync.Once
+	replacer     *strings.Replacer
+}
+
+// A FilterOption configures a FilterWriter.
+type FilterOption func(*FilterWriter)
+
+// FilterLevel drops records below lv.
+func FilterLevel(lv Level) FilterOption {
+	return func(w *FilterWriter) { w.level = lv }
+}
+
+// FilterSubstring drops records whose message contains none of substrs.
+func FilterSubstring(substrs ...string) FilterOption {
+	return func(w *FilterWriter) {
+		for _, s := range substrs {
+			w.substrs = append(w.substrs, []byte(s))
+		}
+	}
+}
+
+// FilterRegexp drops records whose message does not match re.
+func FilterRegexp(re *regexp.Regexp) FilterOption {
+	return func(w *FilterWriter) { w.re = re }
+}
+
+// FilterRedact replaces every occurrence of each key in table with its
+// value (typically "***") before a record reaches the wrapped Writer.
+// FilterRedact may be given more than once; later tables are merged into
+// earlier ones.
+func FilterRedact(table map[string]string) FilterOption {
+	return func(w *FilterWriter) {
+		if w.redact == nil {
+			w.redact = make(map[string][]byte, len(table))
+		}
+
+		for k, v := range table {
+			w.redact[k] = []byte(v)
+		}
+	}
+}
+
+// FilterFunc drops a record at Level lv with content p when fn returns
+// false.
+func FilterFunc(fn func(lv Level, p []byte) bool) FilterOption {
+	return func(w *FilterWriter) { w.filterFn = fn }
+}
+
+// NewFilterWriter wraps w, applying opts to every record before it is
+// written to w.
+func NewFilterWriter(w Writer, opts ...FilterOption) *FilterWriter {
+	fw := &FilterWriter{w: w, level: LevelTrace}
+
+	for _, opt := range opts {
+		opt(fw)
+	}
+
+	return fw
+}
+
+// Writable reports whether lv passes FilterLevel and the wrapped Writer is
+// itself Writable at lv.
+func (w *FilterWriter) Writable(lv Level) bool {
+	return lv >= w.level && w.w.Writable(lv)
+}
+
+// WriteLevel drops p, known to be at Level lv, if it fails any configured
+// filter, otherwise redacts it and passes it on to the wrapped Writer.
+// This is the routing path New uses; it never re-derives lv from p's text.
+func (w *FilterWriter) WriteLevel(lv Level, p []byte) (n int, err error) {
+	if !w.passes(lv, p) {
+		return len(p), nil
+	}
+
+	return writeLevel(w.w, lv, w.redactBytes(p))
+}
+
+// Write drops p if it fails any configured filter, otherwise redacts it
+// and passes it on to the wrapped Writer. Write is a fallback for callers
+// that invoke w directly without a known Level, and detects one from p's
+// "[LEVEL]" substitution; prefer routing w through New, which calls
+// WriteLevel with the real Level instead.
+func (w *FilterWriter) Write(p []byte) (n int, err error) {
+	lv, _ := detectLevel(p)
+	return w.WriteLevel(lv, p)
+}
+
+// WriteRecord drops rec, known to be at Level rec.Level, if it fails any
+// configured filter, evaluated against the same formatted line Write would
+// have seen; otherwise it redacts rec and forwards it to the wrapped
+// Writer, preserving the structured Fields if that Writer is itself a
+// RecordWriter, or redacting the same formatted line passes already built
+// if it isn't.
+func (w *FilterWriter) WriteRecord(rec Record) error {
+	line := formatRecordLine(rec)
+	if !w.passes(rec.Level, line) {
+		return nil
+	}
+
+	if rw, ok := w.w.(RecordWriter); ok {
+		return rw.WriteRecord(w.redactRecord(rec))
+	}
+
+	_, err := writeLevel(w.w, rec.Level, w.redactBytes(line))
+
+	return err
+}
+
+func (w *FilterWriter) passes(lv Level, p []byte) bool {
+	if lv < w.level {
+		return false
+	}
+
+	if len(w.substrs) > 0 {
+		matched := false
+
+		for _, s := range w.substrs {
+			if bytes.Contains(p, s) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if w.re != nil && !w.re.Match(p) {
+		return false
+	}
+
+	if w.filterFn != nil && !w.filterFn(lv, p) {
+		return false
+	}
+
+	return true
+}
+
+// redactBytes replaces every configured redaction token in p in a single
+// simultaneous pass, via strings.Replacer. A single pass, rather than one
+// bytes.ReplaceAll per entry, matters once entries can overlap (e.g. one
+// entry's replacement text is another entry's match): replacing
+// sequentially would feed each entry's output into the next, chaining
+// matches in whatever order the redact map happens to iterate.
+func (w *FilterWriter) redactBytes(p []byte) []byte {
+	if len(w.redact) == 0 {
+		return p
+	}
+
+	return []byte(w.fieldReplacer().Replace(string(p)))
+}
+
+// redactRecord returns a copy of rec with its Msg and any string Fields
+// values passed through redactBytes.
+func (w *FilterWriter) redactRecord(rec Record) Record {
+	if len(w.redact) == 0 {
+		return rec
+	}
+
+	rec.Msg = string(w.redactBytes([]byte(rec.Msg)))
+
+	if len(rec.Fields) > 0 {
+		fields := rec.Fields.clone()
+
+		for k, v := range fields {
+			if s, ok := v.(string); ok {
+				fields[k] = string(w.redactBytes([]byte(s)))
+			}
+		}
+
+		rec.Fields = fields
+	}
+
+	return rec
+}
+
+// fieldReplacer builds w's strings.Replacer once, from keys ordered
+// longest-first (ties broken alphabetically for full determinism). Where
+// two redaction keys overlap as prefixes of each other (e.g. "sec" and
+// "secret"), strings.Replacer resolves the overlap by argument order, not
+// by match length, so building pairs straight from map iteration would
+// make the more specific key win or lose at random from one process run
+// to the next; ordering longest-first makes the more specific key always
+// win.
+func (w *FilterWriter) fieldReplacer() *strings.Replacer {
+	w.replacerOnce.Do(func() {
+		keys := make([]string, 0, len(w.redact))
+		for k := range w.redact {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) > len(keys[j])
+			}
+
+			return keys[i] < keys[j]
+		})
+
+		pairs := make([]string, 0, len(keys)*2)
+		for _, k := range keys {
+			pairs = append(pairs, k, string(w.redact[k]))
+		}
+
+		w.replacer = strings.NewReplacer(pairs...)
+	})
+
+	return w.replacer
+}
@@ -0,0 +1,99 @@
+package log
+
+import "testing"
+
+func TestFilterWriterDropsBySubstring(t *testing.T) {
+	sink := &recordingWriter{level: LevelTrace}
+	w := NewFilterWriter(sink, FilterSubstring("payment"))
+
+	l := New(w, "", 0)
+	l.Info("user login")
+	l.Info("payment succeeded")
+
+	if len(sink.lines) != 1 {
+		t.Fatalf("got %d lines, want 1: %q", len(sink.lines), sink.lines)
+	}
+}
+
+func TestFilterWriterRedactsOverlappingTokensDeterministically(t *testing.T) {
+	sink := &recordingWriter{level: LevelTrace}
+	// "secret" redacts to "***", and "***" itself redacts to "REDACTED":
+	// a sequential, map-iteration-order-dependent replace could feed the
+	// first substitution's output into the second and chain into
+	// "REDACTED" only sometimes; a single simultaneous pass must not.
+	w := NewFilterWriter(sink, FilterRedact(map[string]string{
+		"secret": "***",
+		"***":    "REDACTED",
+	}))
+
+	for i := 0; i < 20; i++ {
+		sink.lines = nil
+
+		l := New(w, "", 0)
+		l.Info("token=secret here")
+
+		if len(sink.lines) != 1 {
+			t.Fatalf("got %d lines, want 1", len(sink.lines))
+		}
+
+		const want = "[INFO] token=*** here\n"
+		if sink.lines[0] != want {
+			t.Fatalf("redacted line = %q, want %q", sink.lines[0], want)
+		}
+	}
+}
+
+func TestFilterWriterRedactsOverlappingPrefixesDeterministically(t *testing.T) {
+	sink := &recordingWriter{level: LevelTrace}
+	// "sec" is a prefix of "secret": strings.Replacer resolves an overlap
+	// like this by argument order, not match length, so building pairs
+	// straight from map iteration would let the shorter or longer key win
+	// at random from one process run to the next. The more specific key,
+	// "secret", must always win.
+	w := NewFilterWriter(sink, FilterRedact(map[string]string{
+		"sec":    "X",
+		"secret": "Y",
+	}))
+
+	for i := 0; i < 20; i++ {
+		sink.lines = nil
+
+		l := New(w, "", 0)
+		l.Info("this is secret")
+
+		if len(sink.lines) != 1 {
+			t.Fatalf("got %d lines, want 1", len(sink.lines))
+		}
+
+		const want = "[INFO] this is Y\n"
+		if sink.lines[0] != want {
+			t.Fatalf("redacted line = %q, want %q", sink.lines[0], want)
+		}
+	}
+}
+
+func TestFilterWriterRedactsRecordFields(t *testing.T) {
+	jw := &recordingRecordWriter{recordingWriter: recordingWriter{level: LevelTrace}}
+	w := NewFilterWriter(jw, FilterRedact(map[string]string{"secret": "***"}))
+
+	l := New(w, "", 0)
+	l.Infow("login", "token", "secret")
+
+	if len(jw.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(jw.records))
+	}
+
+	if got := jw.records[0].Fields["token"]; got != "***" {
+		t.Fatalf("fields[token] = %v, want %q", got, "***")
+	}
+}
+
+type recordingRecordWriter struct {
+	recordingWriter
+	records []Record
+}
+
+func (w *recordingRecordWriter) WriteRecord(rec Record) error {
+	w.records = append(w.records, rec)
+	return nil
+}
@@ -0,0 +1,114 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A JSONWriter is a Writer that encodes each Record as a single JSON object,
+// one per line, and writes it to an underlying io.Writer. It implements
+// RecordWriter, so Records from Infow and friends reach it directly,
+// bypassing the [LEVEL] substitution that Write performs for the plain
+// Error/Warn/... methods. It also implements LevelWriter, so plain calls
+// like Error still land with the correct Level and a clean Msg. InfoCtx
+// and the other *Ctx methods reach it through WriteRecord.
+type JSONWriter struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level Level
+}
+
+// NewJSONWriter creates a JSONWriter that writes to out, accepting records
+// at lv and more severe. Pass LevelTrace to accept everything.
+func NewJSONWriter(out io.Writer, lv Level) *JSONWriter {
+	return &JSONWriter{out: out, level: lv}
+}
+
+// Writable reports whether w accepts records at Level lv.
+func (w *JSONWriter) Writable(lv Level) bool {
+	return lv >= w.level
+}
+
+type jsonRecord struct {
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level"`
+	Msg    string    `json:"msg"`
+	Fields Fields    `json:"fields,omitempty"`
+}
+
+// WriteRecord encodes rec as a JSON object and writes it to the underlying
+// writer, followed by a newline. It does nothing if w is not Writable at
+// rec.Level.
+func (w *JSONWriter) WriteRecord(rec Record) error {
+	if !w.Writable(rec.Level) {
+		return nil
+	}
+
+	b, err := json.Marshal(jsonRecord{
+		Time:   rec.Time,
+		Level:  rec.Level.String(),
+		Msg:    rec.Msg,
+		Fields: rec.Fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	b = append(b, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err = w.out.Write(b)
+	return err
+}
+
+// WriteLevel implements LevelWriter for callers that use w as the
+// destination passed to New directly: p arrives already formatted with its
+// "[LEVEL] " marker substituted in (see New). WriteLevel strips that
+// marker, since lv is already known precisely, and wraps what remains as
+// the Msg of a Record at lv, with no Fields. Prefer Infow and friends,
+// which route through WriteRecord with real Fields instead.
+func (w *JSONWriter) WriteLevel(lv Level, p []byte) (n int, err error) {
+	msg := stripLevelMarker(string(bytes.TrimRight(p, "\n")), lv)
+
+	err = w.WriteRecord(Record{
+		Time:  time.Now(),
+		Level: lv,
+		Msg:   msg,
+	})
+
+	return len(p), err
+}
+
+// Write implements Writer for callers that invoke w directly without a
+// known Level: it best-effort detects one from p's "[LEVEL]" substitution,
+// defaulting to LevelInfo if none is found, then delegates to WriteLevel.
+func (w *JSONWriter) Write(p []byte) (n int, err error) {
+	lv, ok := detectLevel(p)
+	if !ok {
+		lv = LevelInfo
+	}
+
+	return w.WriteLevel(lv, p)
+}
+
+// stripLevelMarker removes the first "[LEVEL]" (or "[LEVEL] ") marker for
+// lv from msg, if present.
+func stripLevelMarker(msg string, lv Level) string {
+	marker := "[" + lv.String() + "] "
+	if i := strings.Index(msg, marker); i >= 0 {
+		return msg[:i] + msg[i+len(marker):]
+	}
+
+	marker = "[" + lv.String() + "]"
+	if i := strings.Index(msg, marker); i >= 0 {
+		return msg[:i] + msg[i+len(marker):]
+	}
+
+	return msg
+}
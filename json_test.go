@@ -0,0 +1,27 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONWriterPlainCallUsesRealLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(NewJSONWriter(&buf, LevelTrace), "", 0)
+	l.Errorf("boom: %s", "disk full")
+
+	var rec jsonRecord
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("unmarshal: %v (line: %q)", err, buf.String())
+	}
+
+	if rec.Level != "ERROR" {
+		t.Errorf("Level = %q, want ERROR", rec.Level)
+	}
+
+	if rec.Msg != "boom: disk full" {
+		t.Errorf("Msg = %q, want %q", rec.Msg, "boom: disk full")
+	}
+}
@@ -3,6 +3,8 @@ package log
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"sync"
@@ -27,6 +29,13 @@ type Logger struct {
 	i *log.Logger
 	d *log.Logger
 	t *log.Logger
+
+	fields Fields
+
+	// Extractor extracts Fields from a context.Context for the *Ctx
+	// methods (ErrorCtx, InfoCtx, and so on). DefaultContextExtractor is
+	// used if Extractor is nil.
+	Extractor ContextExtractor
 }
 
 const levelPlaceHolder = "[#####]"
@@ -93,6 +102,38 @@ func (l *Logger) Errorln(v ...interface{}) {
 	}
 }
 
+// Errorw logs msg at LevelError with kv, a list of alternating field names
+// and values, attached as structured Fields.
+func (l *Logger) Errorw(msg string, kv ...interface{}) {
+	if l.ErrorWritable() {
+		l.logw(LevelError, msg, kv)
+	}
+}
+
+// ErrorCtx logs a message at LevelError, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) ErrorCtx(ctx context.Context, v ...interface{}) {
+	if l.ErrorWritable() {
+		l.logCtx(LevelError, ctx, fmt.Sprint(v...))
+	}
+}
+
+// ErrorfCtx logs a message at LevelError, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) ErrorfCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.ErrorWritable() {
+		l.logCtx(LevelError, ctx, fmt.Sprintf(format, v...))
+	}
+}
+
+// ErrorlnCtx logs a message at LevelError, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) ErrorlnCtx(ctx context.Context, v ...interface{}) {
+	if l.ErrorWritable() {
+		l.logCtx(LevelError, ctx, fmt.Sprintln(v...))
+	}
+}
+
 // ErrorWritable reports whether l can write messages at LevelError.
 func (l *Logger) ErrorWritable() bool {
 	return l.Writable(LevelError)
@@ -119,6 +160,38 @@ func (l *Logger) Warnln(v ...interface{}) {
 	}
 }
 
+// Warnw logs msg at LevelWarn with kv, a list of alternating field names
+// and values, attached as structured Fields.
+func (l *Logger) Warnw(msg string, kv ...interface{}) {
+	if l.WarnWritable() {
+		l.logw(LevelWarn, msg, kv)
+	}
+}
+
+// WarnCtx logs a message at LevelWarn, attaching Fields extracted from ctx
+// by l.Extractor.
+func (l *Logger) WarnCtx(ctx context.Context, v ...interface{}) {
+	if l.WarnWritable() {
+		l.logCtx(LevelWarn, ctx, fmt.Sprint(v...))
+	}
+}
+
+// WarnfCtx logs a message at LevelWarn, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) WarnfCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.WarnWritable() {
+		l.logCtx(LevelWarn, ctx, fmt.Sprintf(format, v...))
+	}
+}
+
+// WarnlnCtx logs a message at LevelWarn, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) WarnlnCtx(ctx context.Context, v ...interface{}) {
+	if l.WarnWritable() {
+		l.logCtx(LevelWarn, ctx, fmt.Sprintln(v...))
+	}
+}
+
 // WarnWritable reports whether l can write messages at LevelWarn.
 func (l *Logger) WarnWritable() bool {
 	return l.Writable(LevelWarn)
@@ -145,6 +218,38 @@ func (l *Logger) Infoln(v ...interface{}) {
 	}
 }
 
+// Infow logs msg at LevelInfo with kv, a list of alternating field names
+// and values, attached as structured Fields.
+func (l *Logger) Infow(msg string, kv ...interface{}) {
+	if l.InfoWritable() {
+		l.logw(LevelInfo, msg, kv)
+	}
+}
+
+// InfoCtx logs a message at LevelInfo, attaching Fields extracted from ctx
+// by l.Extractor.
+func (l *Logger) InfoCtx(ctx context.Context, v ...interface{}) {
+	if l.InfoWritable() {
+		l.logCtx(LevelInfo, ctx, fmt.Sprint(v...))
+	}
+}
+
+// InfofCtx logs a message at LevelInfo, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) InfofCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.InfoWritable() {
+		l.logCtx(LevelInfo, ctx, fmt.Sprintf(format, v...))
+	}
+}
+
+// InfolnCtx logs a message at LevelInfo, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) InfolnCtx(ctx context.Context, v ...interface{}) {
+	if l.InfoWritable() {
+		l.logCtx(LevelInfo, ctx, fmt.Sprintln(v...))
+	}
+}
+
 // InfoWritable reports whether l can write messages at LevelInfo.
 func (l *Logger) InfoWritable() bool {
 	return l.Writable(LevelInfo)
@@ -171,6 +276,38 @@ func (l *Logger) Debugln(v ...interface{}) {
 	}
 }
 
+// Debugw logs msg at LevelDebug with kv, a list of alternating field names
+// and values, attached as structured Fields.
+func (l *Logger) Debugw(msg string, kv ...interface{}) {
+	if l.DebugWritable() {
+		l.logw(LevelDebug, msg, kv)
+	}
+}
+
+// DebugCtx logs a message at LevelDebug, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) DebugCtx(ctx context.Context, v ...interface{}) {
+	if l.DebugWritable() {
+		l.logCtx(LevelDebug, ctx, fmt.Sprint(v...))
+	}
+}
+
+// DebugfCtx logs a message at LevelDebug, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) DebugfCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.DebugWritable() {
+		l.logCtx(LevelDebug, ctx, fmt.Sprintf(format, v...))
+	}
+}
+
+// DebuglnCtx logs a message at LevelDebug, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) DebuglnCtx(ctx context.Context, v ...interface{}) {
+	if l.DebugWritable() {
+		l.logCtx(LevelDebug, ctx, fmt.Sprintln(v...))
+	}
+}
+
 // DebugWritable reports whether l can write messages at LevelDebug.
 func (l *Logger) DebugWritable() bool {
 	return l.Writable(LevelDebug)
@@ -197,6 +334,38 @@ func (l *Logger) Traceln(v ...interface{}) {
 	}
 }
 
+// Tracew logs msg at LevelTrace with kv, a list of alternating field names
+// and values, attached as structured Fields.
+func (l *Logger) Tracew(msg string, kv ...interface{}) {
+	if l.TraceWritable() {
+		l.logw(LevelTrace, msg, kv)
+	}
+}
+
+// TraceCtx logs a message at LevelTrace, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) TraceCtx(ctx context.Context, v ...interface{}) {
+	if l.TraceWritable() {
+		l.logCtx(LevelTrace, ctx, fmt.Sprint(v...))
+	}
+}
+
+// TracefCtx logs a message at LevelTrace, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) TracefCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.TraceWritable() {
+		l.logCtx(LevelTrace, ctx, fmt.Sprintf(format, v...))
+	}
+}
+
+// TracelnCtx logs a message at LevelTrace, attaching Fields extracted from
+// ctx by l.Extractor.
+func (l *Logger) TracelnCtx(ctx context.Context, v ...interface{}) {
+	if l.TraceWritable() {
+		l.logCtx(LevelTrace, ctx, fmt.Sprintln(v...))
+	}
+}
+
 // TraceWritable reports whether l can write messages at LevelTrace.
 func (l *Logger) TraceWritable() bool {
 	return l.Writable(LevelTrace)
@@ -225,6 +394,10 @@ func (w *writer) Write(p []byte) (n int, err error) {
 		p = s
 	}
 
+	if lw, ok := w.w.(LevelWriter); ok {
+		return lw.WriteLevel(w.lv, p)
+	}
+
 	return w.w.Write(p)
 }
 
@@ -0,0 +1,134 @@
+package log
+
+import "bytes"
+
+// A LevelWriter is a Writer that can receive a record's real Level
+// directly, instead of having to re-derive it by inspecting the formatted
+// "[LEVEL]" text. New's internal writer calls WriteLevel when out
+// implements LevelWriter, so MultiWriter, FilterWriter, and SampledWriter
+// route and gate on the Level Logger actually logged at, never on text
+// that merely happens to contain bracketed level names.
+type LevelWriter interface {
+	Writer
+	WriteLevel(lv Level, p []byte) (n int, err error)
+}
+
+// writeLevel forwards p, known to be at Level lv, to w: via WriteLevel if w
+// is a LevelWriter, so the real Level threads through a chain of wrapping
+// Writers, or via plain Write otherwise.
+func writeLevel(w Writer, lv Level, p []byte) (int, error) {
+	if lw, ok := w.(LevelWriter); ok {
+		return lw.WriteLevel(lv, p)
+	}
+
+	return w.Write(p)
+}
+
+// A MultiWriter fans a record out to several Writer sinks, each of which
+// independently decides, via its own Writable, whether it wants records at
+// a given Level. This lets one Logger send, say, ERROR to a remote sink
+// while DEBUG goes only to a local file.
+type MultiWriter struct {
+	writers []Writer
+}
+
+// NewMultiWriter creates a MultiWriter that writes to all of writers.
+func NewMultiWriter(writers ...Writer) *MultiWriter {
+	return &MultiWriter{writers: writers}
+}
+
+// Writable reports whether any of w's writers is Writable at Level lv.
+func (w *MultiWriter) Writable(lv Level) bool {
+	for _, sink := range w.writers {
+		if sink.Writable(lv) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteLevel writes p, known to be at Level lv, to every writer in w that
+// is Writable at lv. This is the routing path New uses, via the internal
+// writer in log.go; it never re-derives lv from p's text.
+func (w *MultiWriter) WriteLevel(lv Level, p []byte) (n int, err error) {
+	for _, sink := range w.writers {
+		if !sink.Writable(lv) {
+			continue
+		}
+
+		if _, werr := writeLevel(sink, lv, p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+
+	return len(p), err
+}
+
+// WriteRecord forwards rec to every writer in w that is Writable at
+// rec.Level, preserving its structured Fields for sinks that are
+// themselves RecordWriters (such as a wrapped JSONWriter) and falling back
+// to a formatted line for those that aren't.
+func (w *MultiWriter) WriteRecord(rec Record) error {
+	var firstErr error
+
+	for _, sink := range w.writers {
+		if !sink.Writable(rec.Level) {
+			continue
+		}
+
+		if err := writeRecord(sink, rec); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Write writes p to every writer in w, gating on the Level found in p's
+// "[LEVEL]" substitution, if any; if none is found, p is sent to every
+// writer unconditionally. Write is a fallback for callers that invoke w
+// directly without a known Level; prefer routing w through New, which
+// calls WriteLevel with the real Level instead of having to guess it from
+// text.
+func (w *MultiWriter) Write(p []byte) (n int, err error) {
+	lv, ok := detectLevel(p)
+	if !ok {
+		for _, sink := range w.writers {
+			if _, werr := sink.Write(p); werr != nil && err == nil {
+				err = werr
+			}
+		}
+
+		return len(p), err
+	}
+
+	return w.WriteLevel(lv, p)
+}
+
+var levelMarkers = [...]struct {
+	marker []byte
+	lv     Level
+}{
+	{[]byte("[ERROR]"), LevelError},
+	{[]byte("[WARN]"), LevelWarn},
+	{[]byte("[INFO]"), LevelInfo},
+	{[]byte("[DEBUG]"), LevelDebug},
+	{[]byte("[TRACE]"), LevelTrace},
+}
+
+// detectLevel is a best-effort fallback used only by Write methods reached
+// without a known Level, i.e. not through New's WriteLevel path: it
+// reports the Level whose bracketed name appears in p, if any. Because it
+// inspects text rather than a real Level, it can be fooled by a message
+// body that happens to contain literal bracket text, so WriteLevel methods
+// must never use it when the real Level is already known.
+func detectLevel(p []byte) (Level, bool) {
+	for _, m := range levelMarkers {
+		if bytes.Contains(p, m.marker) {
+			return m.lv, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,31 @@
+package log
+
+import "testing"
+
+type recordingWriter struct {
+	level Level
+	lines []string
+}
+
+func (w *recordingWriter) Writable(lv Level) bool { return lv >= w.level }
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.lines = append(w.lines, string(p))
+	return len(p), nil
+}
+
+func TestMultiWriterRoutesByRealLevel(t *testing.T) {
+	errSink := &recordingWriter{level: LevelError}
+	debugSink := &recordingWriter{level: LevelDebug}
+
+	l := New(NewMultiWriter(errSink, debugSink), "", 0)
+	l.Debugf("raw upstream response: %s", "[ERROR] invalid token")
+
+	if len(errSink.lines) != 0 {
+		t.Fatalf("error-only sink got %d lines, want 0: %q", len(errSink.lines), errSink.lines)
+	}
+
+	if len(debugSink.lines) != 1 {
+		t.Fatalf("debug sink got %d lines, want 1", len(debugSink.lines))
+	}
+}
@@ -0,0 +1,150 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A Record is a structured log entry produced by Logger's *w methods
+// (Infow, Errorw, and so on).
+type Record struct {
+	Time   time.Time
+	Level  Level
+	Msg    string
+	Fields Fields
+}
+
+// A RecordWriter is a Writer that also accepts Records directly, bypassing
+// the [LEVEL] substitution that plain Writer implementations receive
+// through New. JSONWriter is a RecordWriter.
+type RecordWriter interface {
+	Writer
+	WriteRecord(Record) error
+}
+
+// logw builds a Record from msg, the Logger's own Fields, and kv (a flat
+// list of alternating string keys and values, as passed to Infow and
+// friends), then routes it to the underlying Writer.
+func (l *Logger) logw(lv Level, msg string, kv []interface{}) {
+	fields := l.fields.clone()
+
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+
+		if fields == nil {
+			fields = make(Fields, (len(kv)-i)/2)
+		}
+
+		fields[key] = kv[i+1]
+	}
+
+	l.dispatch(lv, msg, fields)
+}
+
+// dispatch routes a Record built from msg and fields to the underlying
+// Writer: directly, if it is a RecordWriter willing to accept lv, or as a
+// formatted line otherwise. msg is trimmed of any trailing newline first,
+// so that a msg ending in a newline doesn't end up with fields, or a JSON
+// msg field, split across two lines.
+func (l *Logger) dispatch(lv Level, msg string, fields Fields) {
+	msg = strings.TrimSuffix(msg, "\n")
+
+	if rw, ok := l.Writer.(RecordWriter); ok && rw.Writable(lv) {
+		rw.WriteRecord(Record{Time: time.Now(), Level: lv, Msg: msg, Fields: fields})
+		return
+	}
+
+	l.Get(lv).Print(appendFields(msg, fields))
+}
+
+// writeRecord forwards rec to w: via WriteRecord, preserving rec.Fields
+// structured, if w is a RecordWriter (such as a JSONWriter reached through
+// a chain of MultiWriter/FilterWriter/SampledWriter); otherwise rec is
+// formatted as a line and written via writeLevel.
+func writeRecord(w Writer, rec Record) error {
+	if rw, ok := w.(RecordWriter); ok {
+		return rw.WriteRecord(rec)
+	}
+
+	_, err := writeLevel(w, rec.Level, formatRecordLine(rec))
+	return err
+}
+
+// formatRecordLine renders rec as a newline-terminated "[LEVEL] msg
+// key=value ..." line, in the style New's internal writer produces for
+// plain calls but without the Logger's prefix or flag header, for use by
+// composable Writers (MultiWriter, FilterWriter, SampledWriter) that
+// receive a Record via WriteRecord but must hand a wrapped sink formatted
+// bytes because that sink isn't itself a RecordWriter.
+func formatRecordLine(rec Record) []byte {
+	return []byte("[" + rec.Level.String() + "] " + appendFields(rec.Msg, rec.Fields) + "\n")
+}
+
+// appendFields renders msg followed by fields as "key=value" pairs, sorted
+// by key for stable output, in the style used when a structured call falls
+// back to a plain text Writer.
+func appendFields(msg string, fields Fields) string {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	s := msg
+	for _, k := range sortedKeys(fields) {
+		s += fmt.Sprintf(" %s=%v", k, fields[k])
+	}
+
+	return s
+}
+
+// prependFields renders fields as "key=value" pairs, sorted by key for
+// stable output, ahead of msg, the style logCtx uses so that the request
+// ID, trace ID, and user ID extracted from a context.Context read first on
+// a plain text line.
+func prependFields(fields Fields, msg string) string {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	s := ""
+	for _, k := range sortedKeys(fields) {
+		s += fmt.Sprintf("%s=%v ", k, fields[k])
+	}
+
+	return s + msg
+}
+
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WithFields returns a derived Logger that attaches fields to every record
+// logged through Infow, Errorw, and friends, in addition to any fields
+// already carried from l. WithFields clones the parent's fields so that l
+// and the returned Logger never share a map.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	merged := l.fields.clone()
+	if merged == nil {
+		merged = make(Fields, len(fields))
+	}
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	child := *l
+	child.fields = merged
+
+	return &child
+}
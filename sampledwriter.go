@@ -0,0 +1,161 @@
+package log
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// A Rate describes how a SampledWriter throttles a repeated message: the
+// first First occurrences within every Every interval are forwarded, and
+// every Thereafter-th occurrence after that.
+type Rate struct {
+	First      int
+	Thereafter int
+	Every      time.Duration
+}
+
+// A SampledWriter wraps a Writer and throttles bursts of identical lines
+// per Level, so that a Trace or Debug call sitting in a hot loop doesn't
+// flood the wrapped Writer. Levels absent from perLevel pass through
+// unsampled.
+type SampledWriter struct {
+	w        Writer
+	perLevel map[Level]Rate
+
+	mu      sync.Mutex
+	buckets map[uint64]*sampleBucket
+	writes  uint64
+}
+
+type sampleBucket struct {
+	reset time.Time
+	count int
+}
+
+// NewSampledWriter wraps w, sampling records per Level according to
+// perLevel.
+func NewSampledWriter(w Writer, perLevel map[Level]Rate) *SampledWriter {
+	return &SampledWriter{
+		w:        w,
+		perLevel: perLevel,
+		buckets:  make(map[uint64]*sampleBucket),
+	}
+}
+
+// Writable delegates to the wrapped Writer; only Write samples.
+func (w *SampledWriter) Writable(lv Level) bool {
+	return w.w.Writable(lv)
+}
+
+// samplePrefixLen is how much of a record, after any [LEVEL] substitution,
+// is hashed to decide whether two records are "the same message".
+const samplePrefixLen = 64
+
+// WriteLevel decides whether to forward p, known to be at Level lv, to the
+// wrapped Writer based on how often its prefix has recurred recently under
+// lv's Rate in perLevel. A dropped record reports len(p), nil, as New's
+// internal writer does for a Level that isn't Writable; a forwarded record
+// reports len(p) and the wrapped Writer's error. This is the routing path
+// New uses; it never re-derives lv from p's text.
+func (w *SampledWriter) WriteLevel(lv Level, p []byte) (n int, err error) {
+	n = len(p)
+
+	rate, ok := w.perLevel[lv]
+	if !ok {
+		_, err = writeLevel(w.w, lv, p)
+		return n, err
+	}
+
+	if !w.allow(lv, p, rate) {
+		return n, nil
+	}
+
+	_, err = writeLevel(w.w, lv, p)
+
+	return n, err
+}
+
+// Write is a fallback for callers that invoke w directly without a known
+// Level, and detects one from p's "[LEVEL]" substitution; prefer routing w
+// through New, which calls WriteLevel with the real Level instead.
+func (w *SampledWriter) Write(p []byte) (n int, err error) {
+	lv, _ := detectLevel(p)
+	return w.WriteLevel(lv, p)
+}
+
+// WriteRecord decides whether to forward rec, known to be at Level
+// rec.Level, based on how often rec.Msg has recurred recently under that
+// Level's Rate in perLevel, then forwards it to the wrapped Writer,
+// preserving its structured Fields if that Writer is itself a
+// RecordWriter.
+func (w *SampledWriter) WriteRecord(rec Record) error {
+	rate, ok := w.perLevel[rec.Level]
+	if !ok {
+		return writeRecord(w.w, rec)
+	}
+
+	if !w.allow(rec.Level, []byte(rec.Msg), rate) {
+		return nil
+	}
+
+	return writeRecord(w.w, rec)
+}
+
+// sweepEvery is how many calls to allow pass between sweeps of buckets
+// whose reset time has passed. Without a sweep, a bucket is only dropped
+// when its exact message prefix recurs after reset, so a hot loop whose
+// messages are all distinct (e.g. each one embeds a timestamp or an index)
+// would otherwise grow buckets without bound.
+const sweepEvery = 1024
+
+func (w *SampledWriter) allow(lv Level, p []byte, rate Rate) bool {
+	prefix := p
+	if len(prefix) > samplePrefixLen {
+		prefix = prefix[:samplePrefixLen]
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte{byte(lv)})
+	h.Write(prefix)
+	key := h.Sum64()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+
+	w.writes++
+	if w.writes%sweepEvery == 0 {
+		w.sweepLocked(now)
+	}
+
+	b, ok := w.buckets[key]
+	if !ok || now.After(b.reset) {
+		b = &sampleBucket{reset: now.Add(rate.Every)}
+		w.buckets[key] = b
+	}
+
+	b.count++
+
+	if b.count <= rate.First {
+		return true
+	}
+
+	thereafter := rate.Thereafter
+	if thereafter <= 0 {
+		thereafter = 1
+	}
+
+	return (b.count-rate.First)%thereafter == 0
+}
+
+// sweepLocked drops every bucket whose reset time has already passed. The
+// caller must hold w.mu.
+func (w *SampledWriter) sweepLocked(now time.Time) {
+	for key, b := range w.buckets {
+		if now.After(b.reset) {
+			delete(w.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampledWriterSweepsExpiredBuckets(t *testing.T) {
+	sink := &recordingWriter{level: LevelTrace}
+	sw := NewSampledWriter(sink, map[Level]Rate{
+		LevelDebug: {First: 1, Thereafter: 1000, Every: time.Nanosecond},
+	})
+
+	// Every message below is distinct, as in a hot loop whose lines embed a
+	// timestamp or an index in their first samplePrefixLen bytes, so
+	// buckets never collide; only a periodic sweep shrinks the map.
+	for i := 0; i < sweepEvery*2; i++ {
+		msg := []byte{byte(i), byte(i >> 8), byte(i >> 16)}
+		sw.allow(LevelDebug, msg, Rate{First: 1, Thereafter: 1000, Every: time.Nanosecond})
+	}
+
+	if got := len(sw.buckets); got > sweepEvery {
+		t.Fatalf("buckets grew unbounded: %d entries after %d distinct messages", got, sweepEvery*2)
+	}
+}